@@ -2,6 +2,9 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,9 +13,16 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"reflect"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Logger struct {
@@ -47,27 +57,189 @@ func (logger *Logger) Info(message string) {
 	}
 }
 
+// ProcessState is the supervisor's view of a process's lifecycle.
+type ProcessState string
+
+const (
+	StateStopped  ProcessState = "Stopped"
+	StateStarting ProcessState = "Starting"
+	StateRunning  ProcessState = "Running"
+	StateBackoff  ProcessState = "Backoff"
+	StateFatal    ProcessState = "Fatal"
+	StateStopping ProcessState = "Stopping"
+)
+
+// LogBroadcaster keeps a bounded ring buffer of the most recent lines a
+// process has written to stdout/stderr and fans each new line out to any
+// live subscribers, such as a /logs/{name}/stream WebSocket connection.
+type LogBroadcaster struct {
+	mu       sync.Mutex
+	capacity int
+	lines    []string
+	start    int
+	count    int
+	subs     map[chan string]struct{}
+}
+
+func newLogBroadcaster(capacity int) *LogBroadcaster {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	return &LogBroadcaster{
+		capacity: capacity,
+		lines:    make([]string, capacity),
+		subs:     make(map[chan string]struct{}),
+	}
+}
+
+// Publish appends a line to the ring buffer and forwards it to subscribers.
+// Slow subscribers have lines dropped rather than blocking the publisher.
+func (b *LogBroadcaster) Publish(line string) {
+	b.mu.Lock()
+	if b.count < b.capacity {
+		b.lines[(b.start+b.count)%b.capacity] = line
+		b.count++
+	} else {
+		b.lines[b.start] = line
+		b.start = (b.start + 1) % b.capacity
+	}
+	subs := make([]chan string, 0, len(b.subs))
+	for ch := range b.subs {
+		subs = append(subs, ch)
+	}
+	b.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// Lines returns a snapshot of the buffered lines, oldest first.
+func (b *LogBroadcaster) Lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]string, b.count)
+	for i := 0; i < b.count; i++ {
+		out[i] = b.lines[(b.start+i)%b.capacity]
+	}
+	return out
+}
+
+func (b *LogBroadcaster) Subscribe() chan string {
+	ch := make(chan string, 64)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *LogBroadcaster) Unsubscribe(ch chan string) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+// HealthCheck configures how a process's health is probed. Type selects the
+// probe: "tcp" (default, a bare dial), "http", "exec", or "grpc".
+type HealthCheck struct {
+	Type               string
+	Path               string   // http: path to GET
+	MinStatus          int      // http: inclusive lower bound of a healthy status, default 200
+	MaxStatus          int      // http: inclusive upper bound of a healthy status, default 299
+	Command            string   // exec: command to run; exit code 0 means healthy
+	Args               []string // exec: arguments for Command
+	Service            string   // grpc: health service name, empty means overall server health
+	TimeoutMs          int
+	IntervalSeconds    int
+	Retries            int
+	StartPeriodSeconds int
+}
+
 type Process struct {
-	Name      string
-	Command   string
-	Args      []string
-	PauseMs   int
-	Port      int
-	CmdObject *exec.Cmd
+	Name                 string
+	Command              string
+	Args                 []string
+	Port                 int
+	ShutdownGraceSeconds int
+	StartSeconds         int
+	StartRetries         int
+	BackoffMs            int
+	LogBufferLines       int
+	LogFile              string
+	LogFileMaxBytes      int64
+	DependsOn            []string
+	ReadyWhen            string
+	HealthCheck          HealthCheck
+	CmdObject            *exec.Cmd
+	stopCh               chan struct{}
+	exitedCh             chan struct{}
+	logs                 *LogBroadcaster
+
+	mu                   sync.Mutex
+	State                ProcessState
+	StartTime            time.Time
+	RestartCount         int
+	LastExitCode         int
+	healthy              bool
+	healthCheckDurations float64
+	healthCheckFailures  int
+
+	logFileMu     sync.Mutex
+	logFileHandle *os.File
+	logFileSize   int64
+
+	// readyCh is re-armed at the start of every start attempt and closed
+	// once ReadyWhen is satisfied; dependents block on the current value
+	// of this channel before starting.
+	readyMu sync.Mutex
+	readyCh chan struct{}
 }
 
 type Config struct {
-	Processes                  []Process
+	Processes                  []*Process
 	LogLevel                   string
 	HealthCheckIntervalSeconds int
+	MetricsEnabled             bool
+
+	// startOrder is the dependency-respecting start order computed from
+	// DependsOn at load time; stopping in reverse gives a safe shutdown order.
+	startOrder []*Process
 }
 
 var (
-	restartMutex sync.Mutex
-	config       Config
-	logger       Logger = Logger{Level: "INFO"}
+	restartMutex sync.Mutex // serializes /restart requests against each other and against reloadConfig
+
+	// configMu guards config.Processes and config.startOrder themselves (the
+	// slice headers), since reloadConfig swaps them in place on SIGHUP while
+	// HTTP handlers read them concurrently. It does not protect the fields of
+	// an individual *Process, which have their own mu.
+	configMu sync.RWMutex
+
+	config               Config
+	logger               Logger = Logger{Level: "INFO"}
+	shutdownCtx, stopApp        = context.WithCancel(context.Background())
 )
 
+// processesSnapshot returns the current process list under configMu. The
+// returned slice itself is never mutated in place (reloadConfig always
+// builds a new one), so callers may range over it after releasing the lock.
+func processesSnapshot() []*Process {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config.Processes
+}
+
+// startOrderSnapshot is the startOrder equivalent of processesSnapshot.
+func startOrderSnapshot() []*Process {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config.startOrder
+}
+
 func (config *Config) loadConfig() {
 	logger.Debug("Loading process manager config...")
 	file, err := os.Open("config.json")
@@ -82,18 +254,106 @@ func (config *Config) loadConfig() {
 		logger.Debug("Error decoding config file: " + err.Error())
 		panic(err)
 	}
+
+	startOrder, err := computeStartOrder(config.Processes)
+	if err != nil {
+		logger.Debug("Invalid process dependency graph: " + err.Error())
+		panic(err)
+	}
+	config.startOrder = startOrder
+
 	logger.SetLevel(config.LogLevel)
 	logger.Debug("Config loaded successfully")
 }
 
+// computeStartOrder topologically sorts processes by DependsOn so that
+// every process appears after all of its dependencies. It fails with a
+// clear error if a dependency is unknown or the graph has a cycle.
+func computeStartOrder(processes []*Process) ([]*Process, error) {
+	const (
+		unvisited = 0
+		visiting  = 1
+		visited   = 2
+	)
+
+	byName := make(map[string]*Process, len(processes))
+	for _, p := range processes {
+		byName[p.Name] = p
+	}
+
+	state := make(map[string]int, len(processes))
+	order := make([]*Process, 0, len(processes))
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycleStart := 0
+			for i, n := range path {
+				if n == name {
+					cycleStart = i
+					break
+				}
+			}
+			cycle := append(append([]string{}, path[cycleStart:]...), name)
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		p := byName[name]
+		if p != nil {
+			for _, dep := range p.DependsOn {
+				if _, ok := byName[dep]; !ok {
+					return fmt.Errorf("process %s depends on unknown process %s", name, dep)
+				}
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		if p != nil {
+			order = append(order, p)
+		}
+		return nil
+	}
+
+	for _, p := range processes {
+		if state[p.Name] == unvisited {
+			if err := visit(p.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
+
 func (config *Config) dumpConfig() {
 	logger.Debug("--- Configuration ---")
 	for _, process := range config.Processes {
 		logger.Debug("Process Name: " + process.Name)
 		logger.Debug("  Command: " + process.Command)
 		logger.Debug("  Args: " + fmt.Sprintf("%v", process.Args))
-		logger.Debug("  PauseMs: " + fmt.Sprintf("%d", process.PauseMs))
 		logger.Debug("  Port: " + fmt.Sprintf("%d", process.Port))
+		logger.Debug("  DependsOn: " + fmt.Sprintf("%v", process.DependsOn))
+		logger.Debug("  ReadyWhen: " + process.ReadyWhen)
+		logger.Debug("  ShutdownGraceSeconds: " + fmt.Sprintf("%d", process.ShutdownGraceSeconds))
+		logger.Debug("  StartSeconds: " + fmt.Sprintf("%d", process.StartSeconds))
+		logger.Debug("  StartRetries: " + fmt.Sprintf("%d", process.StartRetries))
+		logger.Debug("  BackoffMs: " + fmt.Sprintf("%d", process.BackoffMs))
+		logger.Debug("  LogBufferLines: " + fmt.Sprintf("%d", process.LogBufferLines))
+		logger.Debug("  LogFile: " + process.LogFile)
+		logger.Debug("  HealthCheck.Type: " + process.HealthCheck.Type)
+		logger.Debug("  HealthCheck.IntervalSeconds: " + fmt.Sprintf("%d", process.HealthCheck.IntervalSeconds))
+		logger.Debug("  HealthCheck.Retries: " + fmt.Sprintf("%d", process.HealthCheck.Retries))
+		logger.Debug("  HealthCheck.StartPeriodSeconds: " + fmt.Sprintf("%d", process.HealthCheck.StartPeriodSeconds))
 	}
 	logger.Debug("Log level: " + config.LogLevel)
 	logger.Debug("HealthCheckIntervalSeconds: " + fmt.Sprintf("%d", config.HealthCheckIntervalSeconds))
@@ -103,9 +363,11 @@ func (config *Config) dumpConfig() {
 func (p *Process) Wait() {
 	logger.Debug(fmt.Sprintf("[Watchdog] Start monitoring process: %s", p.Name))
 	err := p.CmdObject.Wait() // Wait for the process to finish
+	exitCode := 0
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+				exitCode = status.ExitStatus()
 				logger.Debug(fmt.Sprintf("[Watchdog] Process %s exited with code: %d", p.Name, status.ExitStatus()))
 				if status.Signaled() {
 					logger.Debug(fmt.Sprintf("[Watchdog] Process %s was terminated by signal: %s", p.Name, status.Signal()))
@@ -117,13 +379,279 @@ func (p *Process) Wait() {
 	} else {
 		logger.Debug(fmt.Sprintf("[Watchdog] Process %s completed successfully", p.Name))
 	}
+	p.mu.Lock()
+	p.LastExitCode = exitCode
+	p.mu.Unlock()
+	close(p.exitedCh)
+}
+
+func (p *Process) setState(state ProcessState) {
+	p.mu.Lock()
+	p.State = state
+	p.mu.Unlock()
+}
+
+func (p *Process) getState() ProcessState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.State
+}
+
+// The config-only fields below (HealthCheck, StartSeconds, StartRetries,
+// BackoffMs, ShutdownGraceSeconds, DependsOn, ReadyWhen, LogFile,
+// LogFileMaxBytes) can be rewritten on a live, running *Process by
+// reloadConfig, so every read of them goes through one of these mu-guarded
+// getters rather than touching the struct field directly.
+
+func (p *Process) getHealthCheck() HealthCheck {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.HealthCheck
+}
+
+func (p *Process) getStartSeconds() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.StartSeconds
+}
+
+func (p *Process) getStartRetries() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.StartRetries
 }
 
+func (p *Process) getBackoffMs() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.BackoffMs
+}
+
+func (p *Process) getShutdownGraceSeconds() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ShutdownGraceSeconds
+}
+
+func (p *Process) getDependsOn() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.DependsOn
+}
+
+func (p *Process) getReadyWhen() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ReadyWhen
+}
+
+// getLogFile returns the configured log file path and its rotation
+// threshold together, since writeLogFile always needs both.
+func (p *Process) getLogFile() (string, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.LogFile, p.LogFileMaxBytes
+}
+
+// watchProcess drives the per-process state machine: Starting -> Running,
+// and on exit either back to Starting (if the process stayed up for at
+// least StartSeconds) or to Backoff with an exponentially growing delay. A
+// process that fails to stay up StartRetries times in a row moves to Fatal
+// and is left alone until an operator hits /restart or /start/{name}.
 func (p *Process) watchProcess() {
+	if p.stopCh == nil {
+		p.stopCh = make(chan struct{})
+	}
+	attempt := 0
 	for {
+		select {
+		case <-p.stopCh:
+			p.setState(StateStopped)
+			logger.Info(fmt.Sprintf("[Watchdog] Process %s is stopped, not starting", p.Name))
+			return
+		default:
+		}
+		if shutdownCtx.Err() != nil {
+			p.setState(StateStopped)
+			logger.Info(fmt.Sprintf("[Watchdog] Manager is shutting down, not starting %s", p.Name))
+			return
+		}
+
+		readyCh := p.resetReady()
+		if !p.waitForDependencies() {
+			p.setState(StateStopped)
+			return
+		}
+
+		p.setState(StateStarting)
+		p.mu.Lock()
+		p.StartTime = time.Now()
+		p.mu.Unlock()
 		p.startProcess()
+		p.setState(StateRunning)
+		// attemptDone bounds awaitReady to this attempt: it's closed the moment
+		// the process exits, so a crash-looping process doesn't accumulate one
+		// forever-polling awaitReady goroutine per failed attempt.
+		attemptDone := make(chan struct{})
+		go p.awaitReady(readyCh, attemptDone)
 		p.Wait()
-		logger.Info(fmt.Sprintf("[Watchdog] Process %s exited, restarting...", p.Name))
+		close(attemptDone)
+
+		p.mu.Lock()
+		uptime := time.Since(p.StartTime)
+		p.RestartCount++
+		p.mu.Unlock()
+
+		select {
+		case <-p.stopCh:
+			p.setState(StateStopped)
+			logger.Info(fmt.Sprintf("[Watchdog] Process %s stopped, not restarting", p.Name))
+			return
+		default:
+		}
+
+		if uptime >= time.Duration(p.getStartSeconds())*time.Second {
+			attempt = 0
+			logger.Info(fmt.Sprintf("[Watchdog] Process %s exited, restarting...", p.Name))
+			continue
+		}
+
+		attempt++
+		if retries := p.getStartRetries(); retries > 0 && attempt >= retries {
+			p.setState(StateFatal)
+			logger.Info(fmt.Sprintf("[Watchdog] Process %s failed to start %d times in a row, marking Fatal", p.Name, attempt))
+			return
+		}
+
+		delay := backoffDelay(p.getBackoffMs(), attempt)
+		p.setState(StateBackoff)
+		logger.Info(fmt.Sprintf("[Watchdog] Process %s exited after %v (attempt %d), backing off for %v", p.Name, uptime, attempt, delay))
+		select {
+		case <-p.stopCh:
+			p.setState(StateStopped)
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoffDelay computes an exponential backoff with a base of baseMs,
+// doubling per attempt and capped at 60 seconds.
+func backoffDelay(baseMs int, attempt int) time.Duration {
+	if baseMs <= 0 {
+		baseMs = 1000
+	}
+	const maxBackoff = 60 * time.Second
+	delay := time.Duration(baseMs) * time.Millisecond
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// resetReady arms a fresh readiness barrier for this start attempt and
+// returns it so the caller can pass the same instance to awaitReady.
+func (p *Process) resetReady() chan struct{} {
+	p.readyMu.Lock()
+	ch := make(chan struct{})
+	p.readyCh = ch
+	p.readyMu.Unlock()
+	return ch
+}
+
+func (p *Process) currentReadyChan() chan struct{} {
+	p.readyMu.Lock()
+	defer p.readyMu.Unlock()
+	return p.readyCh
+}
+
+func (p *Process) markReady(ch chan struct{}) {
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// waitForDependencies blocks until every process named in DependsOn has
+// reached its own ReadyWhen condition for the current start attempt. It
+// returns false if the wait was interrupted by a stop/shutdown request.
+func (p *Process) waitForDependencies() bool {
+	for _, depName := range p.getDependsOn() {
+		dep := findProcess(depName)
+		if dep == nil {
+			continue
+		}
+		ch := dep.currentReadyChan()
+		for ch == nil {
+			// The dependency's watchProcess has not armed its barrier yet.
+			select {
+			case <-p.stopCh:
+				return false
+			case <-shutdownCtx.Done():
+				return false
+			case <-time.After(100 * time.Millisecond):
+			}
+			ch = dep.currentReadyChan()
+		}
+		select {
+		case <-ch:
+		case <-p.stopCh:
+			return false
+		case <-shutdownCtx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// awaitReady watches for ReadyWhen to become true and marks readyCh once it
+// does: "started" is immediate, "port-open" polls canConnectToProcess, and
+// "healthy" polls the process's configured health check. attemptDone is
+// closed by watchProcess the moment this start attempt's process exits, so a
+// crash-looping process can't leave a polling goroutine behind per attempt.
+func (p *Process) awaitReady(readyCh chan struct{}, attemptDone chan struct{}) {
+	readyWhen := strings.ToLower(p.getReadyWhen())
+	if readyWhen == "" || readyWhen == "started" {
+		p.markReady(readyCh)
+		return
+	}
+
+	for {
+		select {
+		case <-readyCh:
+			return
+		case <-attemptDone:
+			return
+		case <-p.stopCh:
+			return
+		case <-shutdownCtx.Done():
+			return
+		default:
+		}
+
+		var ready bool
+		switch readyWhen {
+		case "port-open":
+			ready = p.canConnectToProcess()
+		case "healthy":
+			ready = p.checkHealth()
+		default:
+			ready = true
+		}
+		if ready {
+			p.markReady(readyCh)
+			return
+		}
+
+		select {
+		case <-attemptDone:
+			return
+		case <-time.After(500 * time.Millisecond):
+		}
 	}
 }
 
@@ -137,10 +665,39 @@ func (process *Process) stopProcess() {
 	}
 }
 
+// stopProcessGracefully sends SIGTERM and gives the process up to
+// ShutdownGraceSeconds to exit on its own before escalating to SIGKILL.
+func (process *Process) stopProcessGracefully() {
+	if process.CmdObject == nil || process.CmdObject.Process == nil {
+		logger.Debug(fmt.Sprintf("%s is not running", process.Name))
+		return
+	}
+
+	pid := process.CmdObject.Process.Pid
+	logger.Debug(fmt.Sprintf("Sending SIGTERM to %s with pid %d", process.Name, pid))
+	if err := process.CmdObject.Process.Signal(syscall.SIGTERM); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to signal %s: %v", process.Name, err))
+	}
+
+	grace := process.getShutdownGraceSeconds()
+	if grace <= 0 {
+		grace = 10
+	}
+
+	select {
+	case <-process.exitedCh:
+		logger.Debug(fmt.Sprintf("%s stopped gracefully", process.Name))
+	case <-time.After(time.Duration(grace) * time.Second):
+		logger.Info(fmt.Sprintf("%s did not stop within %ds, sending SIGKILL", process.Name, grace))
+		process.CmdObject.Process.Kill()
+		<-process.exitedCh
+	}
+}
+
 func (process *Process) startProcess() {
 	logger.Debug(fmt.Sprintf("Starting %s with command %s and args %v", process.Name, process.Command, process.Args))
-	time.Sleep(time.Duration(process.PauseMs) * time.Millisecond)
 	process.CmdObject = exec.Command(process.Command, process.Args...)
+	process.exitedCh = make(chan struct{})
 
 	stdoutPipe, err := process.CmdObject.StdoutPipe()
 	if err != nil {
@@ -168,16 +725,79 @@ func (process *Process) startProcess() {
 func (process *Process) captureOutput(pipe io.ReadCloser) {
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
-		logger.Debug(fmt.Sprintf("[%s] %s", process.Name, scanner.Text()))
+		line := scanner.Text()
+		logger.Debug(fmt.Sprintf("[%s] %s", process.Name, line))
+		if process.logs != nil {
+			process.logs.Publish(line)
+		}
+		process.writeLogFile(line)
 	}
 	if err := scanner.Err(); err != nil {
 		logger.Debug(fmt.Sprintf("Error reading from pipe for %s: %v", process.Name, err))
 	}
 }
 
+// writeLogFile appends a line to the process's optional file sink,
+// rotating it once it grows past LogFileMaxBytes.
+func (process *Process) writeLogFile(line string) {
+	logFile, maxBytes := process.getLogFile()
+	if logFile == "" {
+		return
+	}
+
+	process.logFileMu.Lock()
+	defer process.logFileMu.Unlock()
+
+	if process.logFileHandle == nil {
+		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Failed to open log file %s for %s: %v", logFile, process.Name, err))
+			return
+		}
+		process.logFileHandle = f
+		process.logFileSize = 0
+		if info, err := f.Stat(); err == nil {
+			process.logFileSize = info.Size()
+		}
+	}
+
+	n, err := process.logFileHandle.WriteString(line + "\n")
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to write to log file for %s: %v", process.Name, err))
+		return
+	}
+	process.logFileSize += int64(n)
+
+	if maxBytes <= 0 {
+		maxBytes = 10 * 1024 * 1024
+	}
+	if process.logFileSize >= maxBytes {
+		process.rotateLogFileLocked(logFile)
+	}
+}
+
+// rotateLogFileLocked renames the current log file aside and closes the
+// handle so the next write reopens (and thus recreates) LogFile. Caller
+// must hold logFileMu.
+func (process *Process) rotateLogFileLocked(logFile string) {
+	process.logFileHandle.Close()
+	process.logFileHandle = nil
+	process.logFileSize = 0
+
+	rotated := fmt.Sprintf("%s.%d", logFile, time.Now().Unix())
+	if err := os.Rename(logFile, rotated); err != nil {
+		logger.Debug(fmt.Sprintf("Failed to rotate log file for %s: %v", process.Name, err))
+	}
+}
+
 func (process *Process) canConnectToProcess() bool {
 	logger.Debug(fmt.Sprintf("Checking network health of %s...", process.Name))
-	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", process.Port), 2*time.Second)
+	hc := process.getHealthCheck()
+	timeout := time.Duration(hc.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", process.Port), timeout)
 	if err != nil {
 		logger.Debug(fmt.Sprintf("Error connecting to %s: %v", process.Name, err))
 		return false
@@ -188,40 +808,410 @@ func (process *Process) canConnectToProcess() bool {
 	}
 }
 
+// checkHTTPHealth GETs HealthCheck.Path and considers the process healthy
+// if the response status falls within [MinStatus, MaxStatus].
+func (process *Process) checkHTTPHealth() bool {
+	hc := process.getHealthCheck()
+	timeout := time.Duration(hc.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	path := hc.Path
+	if path == "" {
+		path = "/"
+	}
+
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d%s", process.Port, path))
+	if err != nil {
+		logger.Debug(fmt.Sprintf("HTTP health check failed for %s: %v", process.Name, err))
+		return false
+	}
+	defer resp.Body.Close()
+
+	minStatus := hc.MinStatus
+	if minStatus == 0 {
+		minStatus = 200
+	}
+	maxStatus := hc.MaxStatus
+	if maxStatus == 0 {
+		maxStatus = 299
+	}
+
+	healthy := resp.StatusCode >= minStatus && resp.StatusCode <= maxStatus
+	if !healthy {
+		logger.Debug(fmt.Sprintf("HTTP health check for %s returned status %d", process.Name, resp.StatusCode))
+	}
+	return healthy
+}
+
+// checkExecHealth runs HealthCheck.Command, like a Docker HEALTHCHECK: a
+// zero exit code means healthy.
+func (process *Process) checkExecHealth() bool {
+	hc := process.getHealthCheck()
+	if hc.Command == "" {
+		logger.Debug(fmt.Sprintf("No exec health check command configured for %s", process.Name))
+		return false
+	}
+	timeout := time.Duration(hc.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hc.Command, hc.Args...)
+	if err := cmd.Run(); err != nil {
+		logger.Debug(fmt.Sprintf("Exec health check failed for %s: %v", process.Name, err))
+		return false
+	}
+	return true
+}
+
+// checkGRPCHealth calls the standard grpc.health.v1 Health/Check RPC
+// against HealthCheck.Service (empty means the server's overall status).
+func (process *Process) checkGRPCHealth() bool {
+	hc := process.getHealthCheck()
+	timeout := time.Duration(hc.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	address := fmt.Sprintf("127.0.0.1:%d", process.Port)
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	if err != nil {
+		logger.Debug(fmt.Sprintf("gRPC health check could not connect to %s: %v", process.Name, err))
+		return false
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: hc.Service})
+	if err != nil {
+		logger.Debug(fmt.Sprintf("gRPC health check failed for %s: %v", process.Name, err))
+		return false
+	}
+	return resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+}
+
+// checkHealth dispatches to the configured HealthCheck.Type, defaulting to
+// the original tcp dial behavior.
+func (process *Process) checkHealth() bool {
+	hc := process.getHealthCheck()
+	switch strings.ToLower(hc.Type) {
+	case "", "tcp":
+		return process.canConnectToProcess()
+	case "http":
+		return process.checkHTTPHealth()
+	case "exec":
+		return process.checkExecHealth()
+	case "grpc":
+		return process.checkGRPCHealth()
+	default:
+		logger.Debug(fmt.Sprintf("Unknown health check type %q for %s, falling back to tcp", hc.Type, process.Name))
+		return process.canConnectToProcess()
+	}
+}
+
+func (process *Process) isHealthy() bool {
+	process.mu.Lock()
+	defer process.mu.Unlock()
+	return process.healthy
+}
+
+func (process *Process) setHealthy(healthy bool) {
+	process.mu.Lock()
+	process.healthy = healthy
+	process.mu.Unlock()
+}
+
 func healthCheck() bool {
-	for i := 0; i < len(config.Processes); i++ {
-		if !config.Processes[i].canConnectToProcess() {
+	for _, p := range processesSnapshot() {
+		if !p.isHealthy() {
 			return false
 		}
 	}
 	return true
 }
 
-func healthCheckLoop() {
+// healthCheckLoop runs this process's configured health check on its own
+// Interval, ignoring failures for StartPeriod after a start, and restarts
+// the process once it has failed Retries times in a row.
+func (process *Process) healthCheckLoop() {
+	failures := 0
 	for {
-		time.Sleep(time.Duration(config.HealthCheckIntervalSeconds) * time.Second)
-		if healthCheck() {
-			logger.Info("Network connection to all processes is healthy")
-		} else {
-			logger.Info("One or more processes are not accessible over network, restarting processes...")
-			if !restartMutex.TryLock() {
-				logger.Info("Restart already in progress, skipping health check")
-				continue
-			} else {
-				restartProcesses()
-			}
-			restartMutex.Unlock()
+		hc := process.getHealthCheck()
+		interval := time.Duration(hc.IntervalSeconds) * time.Second
+		if interval <= 0 {
+			interval = time.Duration(config.HealthCheckIntervalSeconds) * time.Second
+		}
+
+		select {
+		case <-shutdownCtx.Done():
+			return
+		case <-process.stopCh:
+			return
+		case <-time.After(interval):
+		}
+
+		if process.getState() != StateRunning {
+			continue
+		}
+
+		process.mu.Lock()
+		startedAt := process.StartTime
+		process.mu.Unlock()
+
+		startPeriod := time.Duration(hc.StartPeriodSeconds) * time.Second
+		if time.Since(startedAt) < startPeriod {
+			logger.Debug(fmt.Sprintf("%s is within its health check start period, skipping check", process.Name))
+			continue
+		}
+
+		checkStart := time.Now()
+		healthy := process.checkHealth()
+		duration := time.Since(checkStart).Seconds()
+		process.setHealthy(healthy)
+
+		process.mu.Lock()
+		process.healthCheckDurations = duration
+		process.mu.Unlock()
+
+		if healthy {
+			failures = 0
+			continue
+		}
+
+		process.mu.Lock()
+		process.healthCheckFailures++
+		process.mu.Unlock()
+
+		failures++
+		retries := hc.Retries
+		if retries <= 0 {
+			retries = 1
+		}
+		logger.Info(fmt.Sprintf("Health check failed for %s (%d/%d)", process.Name, failures, retries))
+		if failures >= retries {
+			logger.Info(fmt.Sprintf("%s failed its health check %d times in a row, restarting", process.Name, failures))
+			failures = 0
+			process.stopProcess()
 		}
 	}
 }
 
+// restartProcesses stops dependents before their dependencies (reverse
+// startOrder) so a process never briefly outlives something it depends on;
+// watchProcess re-starts each one in forward order once it observes the exit.
 func restartProcesses() {
-	for i := 0; i < len(config.Processes); i++ {
-		config.Processes[i].stopProcess()
+	order := startOrderSnapshot()
+	if len(order) == 0 {
+		order = processesSnapshot()
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		p := order[i]
+		if p.getState() == StateFatal {
+			logger.Info(fmt.Sprintf("Process %s is Fatal, restarting it", p.Name))
+			p.setState(StateStopped)
+			go p.watchProcess()
+			continue
+		}
+		p.stopProcess()
 		// we don't need to start the process here, the watchProcess function will do it
 	}
 }
 
+// shutdownAll stops every supervised process, signalling watchProcess loops
+// to give up their restart loop and draining children in parallel via SIGTERM
+// with an escalation to SIGKILL after each process's grace period.
+func shutdownAll() {
+	logger.Info("Stopping all processes...")
+	processes := processesSnapshot()
+	var wg sync.WaitGroup
+	for i := range processes {
+		p := processes[i]
+		if p.stopCh != nil {
+			close(p.stopCh)
+		}
+		wg.Add(1)
+		go func(p *Process) {
+			defer wg.Done()
+			p.stopProcessGracefully()
+		}(p)
+	}
+	wg.Wait()
+	logger.Info("All processes stopped")
+}
+
+func equalArgs(a, b []string) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// reloadConfig re-reads config.json and reconciles the running processes
+// with the new definitions: processes removed from the file are stopped,
+// new entries are started, and entries whose command/args/port changed are
+// restarted with the new settings. Unchanged processes are left running.
+func reloadConfig() {
+	file, err := os.Open("config.json")
+	if err != nil {
+		logger.Info("Error reloading config: " + err.Error())
+		return
+	}
+	defer file.Close()
+
+	var newConfig Config
+	decoder := json.NewDecoder(file)
+	if err := decoder.Decode(&newConfig); err != nil {
+		logger.Info("Error decoding reloaded config: " + err.Error())
+		return
+	}
+
+	if _, err := computeStartOrder(newConfig.Processes); err != nil {
+		logger.Info("Rejected config reload due to invalid dependency graph: " + err.Error())
+		return
+	}
+
+	restartMutex.Lock()
+	defer restartMutex.Unlock()
+
+	// existing is a stable view of the current process list: reloadConfig is
+	// the only writer of config.Processes, and it only runs from this single,
+	// restartMutex-serialized call site, so reading it under RLock (rather
+	// than the Lock taken below only for the swap) keeps HTTP handlers from
+	// blocking for the full reconciliation below.
+	configMu.RLock()
+	existing := config.Processes
+	configMu.RUnlock()
+
+	byName := make(map[string]int, len(existing))
+	for i, p := range existing {
+		byName[p.Name] = i
+	}
+
+	// toStop collects processes that need a graceful stop (changed command/
+	// args/port, or removed entirely); these run concurrently below, outside
+	// configMu, since each can block for up to ShutdownGraceSeconds.
+	var toStop []*Process
+
+	wanted := make(map[string]bool, len(newConfig.Processes))
+	updated := make([]*Process, 0, len(newConfig.Processes))
+	for _, np := range newConfig.Processes {
+		wanted[np.Name] = true
+		if i, ok := byName[np.Name]; ok {
+			old := existing[i]
+			if old.Command != np.Command || !equalArgs(old.Args, np.Args) || old.Port != np.Port {
+				logger.Info(fmt.Sprintf("Process %s changed, restarting with new settings", np.Name))
+				if old.stopCh != nil {
+					close(old.stopCh)
+				}
+				toStop = append(toStop, old)
+				np.stopCh = make(chan struct{})
+				np.logs = old.logs // keep log history across a restart
+				updated = append(updated, np)
+			} else {
+				// Command/Args/Port are unchanged, so keep the process running and
+				// just adopt every other config-only field from np; runtime state
+				// (CmdObject, stopCh, exitedCh, logs, mu-guarded counters) stays on
+				// old. These fields are read from other goroutines through their
+				// mu-guarded get* accessors (watchProcess, the health checks,
+				// writeLogFile), so mu guards the update here too.
+				old.mu.Lock()
+				old.DependsOn = np.DependsOn
+				old.ReadyWhen = np.ReadyWhen
+				old.HealthCheck = np.HealthCheck
+				old.ShutdownGraceSeconds = np.ShutdownGraceSeconds
+				old.StartSeconds = np.StartSeconds
+				old.StartRetries = np.StartRetries
+				old.BackoffMs = np.BackoffMs
+				old.LogBufferLines = np.LogBufferLines
+				old.LogFile = np.LogFile
+				old.LogFileMaxBytes = np.LogFileMaxBytes
+				old.mu.Unlock()
+				updated = append(updated, old)
+			}
+		} else {
+			logger.Info(fmt.Sprintf("New process %s found in config, starting...", np.Name))
+			np.stopCh = make(chan struct{})
+			np.logs = newLogBroadcaster(np.LogBufferLines)
+			updated = append(updated, np)
+		}
+	}
+
+	for i := range existing {
+		old := existing[i]
+		if !wanted[old.Name] {
+			logger.Info(fmt.Sprintf("Process %s removed from config, stopping...", old.Name))
+			if old.stopCh != nil {
+				close(old.stopCh)
+			}
+			toStop = append(toStop, old)
+		}
+	}
+
+	// Stop changed/removed processes in parallel and wait for all of them
+	// before publishing the new process list, so a dependency's replacement
+	// never starts before the old instance has actually exited - but without
+	// holding configMu (and blocking every HTTP handler) for the sum of their
+	// grace periods.
+	var wg sync.WaitGroup
+	for _, p := range toStop {
+		wg.Add(1)
+		go func(p *Process) {
+			defer wg.Done()
+			p.stopProcessGracefully()
+		}(p)
+	}
+	wg.Wait()
+
+	configMu.Lock()
+	config.Processes = updated
+	config.startOrder, _ = computeStartOrder(updated)
+	configMu.Unlock()
+
+	config.LogLevel = newConfig.LogLevel
+	config.HealthCheckIntervalSeconds = newConfig.HealthCheckIntervalSeconds
+	logger.SetLevel(newConfig.LogLevel)
+
+	for _, p := range updated {
+		if p.CmdObject == nil {
+			p.setHealthy(true)
+			go p.watchProcess()
+			go p.healthCheckLoop()
+		}
+	}
+
+	logger.Info("Configuration reloaded")
+}
+
+// listenForSignals installs handlers for SIGINT/SIGTERM/SIGHUP. SIGHUP
+// triggers a config reload in place; SIGINT/SIGTERM drain all processes and
+// shut down the HTTP server gracefully.
+func listenForSignals(httpServer *http.Server) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	for sig := range sigCh {
+		switch sig {
+		case syscall.SIGHUP:
+			logger.Info("Received SIGHUP, reloading configuration...")
+			reloadConfig()
+		default:
+			logger.Info(fmt.Sprintf("Received %s, shutting down...", sig))
+			stopApp()
+			shutdownAll()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := httpServer.Shutdown(ctx); err != nil {
+				logger.Info("Error shutting down HTTP server: " + err.Error())
+			}
+			return
+		}
+	}
+}
+
 // handlers
 // healthcheck handler
 func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
@@ -256,6 +1246,343 @@ func restartHandler(w http.ResponseWriter) {
 	}
 }
 
+// ProcessStatusView is the JSON representation of a process's supervisor
+// state, returned by the /status endpoint.
+type ProcessStatusView struct {
+	Name          string  `json:"name"`
+	State         string  `json:"state"`
+	Pid           int     `json:"pid,omitempty"`
+	UptimeSeconds float64 `json:"uptimeSeconds"`
+	RestartCount  int     `json:"restartCount"`
+	LastExitCode  int     `json:"lastExitCode"`
+}
+
+func (process *Process) statusView() ProcessStatusView {
+	process.mu.Lock()
+	defer process.mu.Unlock()
+
+	view := ProcessStatusView{
+		Name:         process.Name,
+		State:        string(process.State),
+		RestartCount: process.RestartCount,
+		LastExitCode: process.LastExitCode,
+	}
+	if process.State == StateRunning && process.CmdObject != nil && process.CmdObject.Process != nil {
+		view.Pid = process.CmdObject.Process.Pid
+		view.UptimeSeconds = time.Since(process.StartTime).Seconds()
+	}
+	return view
+}
+
+// status handler
+func statusHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/status"), "/")
+
+	var views []ProcessStatusView
+	for _, p := range processesSnapshot() {
+		if name != "" && p.Name != name {
+			continue
+		}
+		views = append(views, p.statusView())
+	}
+
+	if name != "" && len(views) == 0 {
+		http.Error(w, fmt.Sprintf("Process %s not found", name), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if name != "" {
+		json.NewEncoder(w).Encode(views[0])
+		return
+	}
+	json.NewEncoder(w).Encode(views)
+}
+
+// writeMetricLine writes a single Prometheus text-exposition-format sample:
+// a metric name, a `name="..."` label, and a float value.
+func writeMetricLine(w io.Writer, metric, name string, value float64) {
+	fmt.Fprintf(w, "%s{name=%q} %v\n", metric, name, value)
+}
+
+// metrics handler: hand-rolled Prometheus text format, no client library.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	processes := processesSnapshot()
+
+	fmt.Fprintln(w, "# HELP processmanager_process_up Whether the process passed its last health check (1) or not (0).")
+	fmt.Fprintln(w, "# TYPE processmanager_process_up gauge")
+	for _, p := range processes {
+		up := 0.0
+		if p.isHealthy() {
+			up = 1.0
+		}
+		writeMetricLine(w, "processmanager_process_up", p.Name, up)
+	}
+
+	fmt.Fprintln(w, "# HELP processmanager_process_restarts_total Number of times the process has been restarted.")
+	fmt.Fprintln(w, "# TYPE processmanager_process_restarts_total counter")
+	for _, p := range processes {
+		p.mu.Lock()
+		restarts := p.RestartCount
+		p.mu.Unlock()
+		writeMetricLine(w, "processmanager_process_restarts_total", p.Name, float64(restarts))
+	}
+
+	fmt.Fprintln(w, "# HELP processmanager_process_start_timestamp_seconds Unix timestamp of the process's last start.")
+	fmt.Fprintln(w, "# TYPE processmanager_process_start_timestamp_seconds gauge")
+	for _, p := range processes {
+		p.mu.Lock()
+		startTime := p.StartTime
+		p.mu.Unlock()
+		writeMetricLine(w, "processmanager_process_start_timestamp_seconds", p.Name, float64(startTime.Unix()))
+	}
+
+	fmt.Fprintln(w, "# HELP processmanager_process_last_exit_code Exit code from the process's last run.")
+	fmt.Fprintln(w, "# TYPE processmanager_process_last_exit_code gauge")
+	for _, p := range processes {
+		p.mu.Lock()
+		lastExitCode := p.LastExitCode
+		p.mu.Unlock()
+		writeMetricLine(w, "processmanager_process_last_exit_code", p.Name, float64(lastExitCode))
+	}
+
+	// Exposed as a gauge of the single latest sample rather than a
+	// histogram/summary: there are no _bucket/_sum/_count series here.
+	fmt.Fprintln(w, "# HELP processmanager_healthcheck_duration_seconds Duration of the process's last health check, in seconds (latest sample, not a distribution).")
+	fmt.Fprintln(w, "# TYPE processmanager_healthcheck_duration_seconds gauge")
+	for _, p := range processes {
+		p.mu.Lock()
+		duration := p.healthCheckDurations
+		p.mu.Unlock()
+		writeMetricLine(w, "processmanager_healthcheck_duration_seconds", p.Name, duration)
+	}
+
+	fmt.Fprintln(w, "# HELP processmanager_healthcheck_failures_total Number of failed health checks for the process.")
+	fmt.Fprintln(w, "# TYPE processmanager_healthcheck_failures_total counter")
+	for _, p := range processes {
+		p.mu.Lock()
+		failures := p.healthCheckFailures
+		p.mu.Unlock()
+		writeMetricLine(w, "processmanager_healthcheck_failures_total", p.Name, float64(failures))
+	}
+}
+
+// start handler: revives a single Fatal or Stopped process by name
+func startHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/start/")
+	if name == "" {
+		http.Error(w, "process name is required", http.StatusBadRequest)
+		return
+	}
+
+	for _, process := range processesSnapshot() {
+		if process.Name != name {
+			continue
+		}
+		logger.Info(fmt.Sprintf("External start request received for %s", process.Name))
+		switch process.getState() {
+		case StateFatal, StateStopped, "":
+			process.setState(StateStopped)
+			go process.watchProcess()
+		default:
+			logger.Debug(fmt.Sprintf("%s is already %s, ignoring start request", process.Name, process.getState()))
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	http.Error(w, fmt.Sprintf("Process %s not found", name), http.StatusNotFound)
+}
+
+func findProcess(name string) *Process {
+	for _, p := range processesSnapshot() {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// logs handler: GET /logs/{name} returns the buffered lines, and
+// GET /logs/{name}/stream upgrades to a WebSocket streaming new lines.
+func logsHandler(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/logs/")
+	name := strings.TrimSuffix(rest, "/stream")
+	stream := strings.HasSuffix(rest, "/stream")
+
+	if name == "" {
+		http.Error(w, "process name is required", http.StatusBadRequest)
+		return
+	}
+
+	process := findProcess(name)
+	if process == nil || process.logs == nil {
+		http.Error(w, fmt.Sprintf("Process %s not found", name), http.StatusNotFound)
+		return
+	}
+
+	if stream {
+		streamProcessLogs(w, r, process)
+		return
+	}
+
+	lines := process.logs.Lines()
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(lines)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+func websocketAcceptKey(clientKey string) string {
+	h := sha1.New()
+	h.Write([]byte(clientKey + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// writeWSTextFrame writes an unmasked, unfragmented text frame, per RFC 6455.
+func writeWSTextFrame(w io.Writer, payload []byte) error {
+	length := len(payload)
+	var header []byte
+	switch {
+	case length <= 125:
+		header = []byte{0x81, byte(length)}
+	case length <= 65535:
+		header = []byte{0x81, 126, byte(length >> 8), byte(length)}
+	default:
+		header = []byte{0x81, 127,
+			byte(length >> 56), byte(length >> 48), byte(length >> 40), byte(length >> 32),
+			byte(length >> 24), byte(length >> 16), byte(length >> 8), byte(length)}
+	}
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+func writeWSCloseFrame(w io.Writer) error {
+	_, err := w.Write([]byte{0x88, 0x00})
+	return err
+}
+
+// readWSFrameIsClose reads and discards a single client frame, reporting
+// whether it was a close frame (or the connection failed/EOF'd, which we
+// also treat as a request to stop streaming).
+func readWSFrameIsClose(r io.Reader) bool {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return true
+	}
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := int64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(r, ext); err != nil {
+			return true
+		}
+		length = int64(ext[0])<<8 | int64(ext[1])
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(r, ext); err != nil {
+			return true
+		}
+		length = 0
+		for _, b := range ext {
+			length = length<<8 | int64(b)
+		}
+	}
+
+	if masked {
+		var maskKey [4]byte
+		if _, err := io.ReadFull(r, maskKey[:]); err != nil {
+			return true
+		}
+	}
+
+	if _, err := io.CopyN(io.Discard, r, length); err != nil {
+		return true
+	}
+
+	return opcode == 0x8 // close opcode
+}
+
+// streamProcessLogs performs a minimal RFC 6455 WebSocket handshake over
+// the hijacked connection and streams new log lines until the client
+// disconnects, sends a close frame, or the process stops.
+func streamProcessLogs(w http.ResponseWriter, r *http.Request, process *Process) {
+	clientKey := r.Header.Get("Sec-WebSocket-Key")
+	if clientKey == "" || !strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		http.Error(w, "expected a WebSocket upgrade request", http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, bufrw, err := hijacker.Hijack()
+	if err != nil {
+		logger.Debug(fmt.Sprintf("Failed to hijack connection for %s log stream: %v", process.Name, err))
+		return
+	}
+	defer conn.Close()
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAcceptKey(clientKey) + "\r\n\r\n"
+	if _, err := bufrw.WriteString(response); err != nil || bufrw.Flush() != nil {
+		logger.Debug(fmt.Sprintf("Failed to complete WebSocket handshake for %s", process.Name))
+		return
+	}
+
+	logger.Info(fmt.Sprintf("Log stream opened for %s", process.Name))
+	sub := process.logs.Subscribe()
+	defer process.logs.Unsubscribe(sub)
+
+	clientClosed := make(chan struct{})
+	go func() {
+		for {
+			if readWSFrameIsClose(bufrw) {
+				close(clientClosed)
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-clientClosed:
+			logger.Info(fmt.Sprintf("Log stream closed for %s", process.Name))
+			return
+		case <-process.stopCh:
+			writeWSCloseFrame(bufrw)
+			bufrw.Flush()
+			return
+		case line, ok := <-sub:
+			if !ok {
+				return
+			}
+			if err := writeWSTextFrame(bufrw, []byte(line)); err != nil || bufrw.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
 func main() {
 
 	logger.Info("Starting process manager...")
@@ -267,19 +1594,33 @@ func main() {
 	// start processes
 	logger.Info("Starting processes...")
 	for i := range config.Processes {
+		config.Processes[i].logs = newLogBroadcaster(config.Processes[i].LogBufferLines)
+		config.Processes[i].setHealthy(true)
 		go config.Processes[i].watchProcess()
+		go config.Processes[i].healthCheckLoop()
 	}
 
-	// start health check process
-	logger.Debug("Starting health check loop...")
-	go healthCheckLoop()
-
 	// start HTTP server
-	http.HandleFunc("/health", healthCheckHandler)
-	http.HandleFunc("/restart", func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", healthCheckHandler)
+	mux.HandleFunc("/restart", func(w http.ResponseWriter, r *http.Request) {
 		restartHandler(w)
 	})
+	mux.HandleFunc("/status", statusHandler)
+	mux.HandleFunc("/status/", statusHandler)
+	mux.HandleFunc("/start/", startHandler)
+	mux.HandleFunc("/logs/", logsHandler)
+	if config.MetricsEnabled {
+		mux.HandleFunc("/metrics", metricsHandler)
+	}
+	httpServer := &http.Server{Addr: ":8080", Handler: mux}
+
+	// install signal handlers for graceful shutdown and config reload
+	go listenForSignals(httpServer)
+
 	logger.Info("Starting HTTP server on port 8080...")
-	http.ListenAndServe(":8080", nil)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Info("HTTP server error: " + err.Error())
+	}
 	logger.Info("HTTP server stopped")
 }
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"net/http/httptest"
 	"os/exec"
+	"strings"
 	"syscall"
 	"testing"
+	"time"
 )
 
 func TestWait_ProcessCompletesSuccessfully(t *testing.T) {
@@ -95,3 +98,134 @@ func TestWait_ProcessKilled(t *testing.T) {
 		t.Fatalf("Expected process to be killed with SIGKILL, but got %v", status.Signal())
 	}
 }
+
+func TestBackoffDelay_DoublesUpToCap(t *testing.T) {
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 1 * time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{7, 60 * time.Second}, // capped
+	}
+
+	for _, c := range cases {
+		got := backoffDelay(1000, c.attempt)
+		if got != c.want {
+			t.Errorf("backoffDelay(1000, %d) = %v, want %v", c.attempt, got, c.want)
+		}
+	}
+}
+
+func TestLogBroadcaster_DropsOldestPastCapacity(t *testing.T) {
+	b := newLogBroadcaster(3)
+
+	for _, line := range []string{"one", "two", "three", "four"} {
+		b.Publish(line)
+	}
+
+	got := b.Lines()
+	want := []string{"two", "three", "four"}
+	if len(got) != len(want) {
+		t.Fatalf("Lines() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Lines()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLogBroadcaster_SubscriberReceivesNewLines(t *testing.T) {
+	b := newLogBroadcaster(10)
+	sub := b.Subscribe()
+	defer b.Unsubscribe(sub)
+
+	b.Publish("hello")
+
+	select {
+	case line := <-sub:
+		if line != "hello" {
+			t.Fatalf("got %q, want %q", line, "hello")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber did not receive published line")
+	}
+}
+
+func TestComputeStartOrder_OrdersDependenciesBeforeDependents(t *testing.T) {
+	api := &Process{Name: "api", DependsOn: []string{"db"}}
+	worker := &Process{Name: "worker", DependsOn: []string{"api"}}
+	db := &Process{Name: "db"}
+
+	order, err := computeStartOrder([]*Process{worker, api, db})
+	if err != nil {
+		t.Fatalf("computeStartOrder returned error: %v", err)
+	}
+
+	index := make(map[string]int, len(order))
+	for i, p := range order {
+		index[p.Name] = i
+	}
+	if index["db"] > index["api"] || index["api"] > index["worker"] {
+		t.Fatalf("expected order db < api < worker, got %v", order)
+	}
+}
+
+func TestComputeStartOrder_DetectsCycle(t *testing.T) {
+	a := &Process{Name: "a", DependsOn: []string{"b"}}
+	b := &Process{Name: "b", DependsOn: []string{"a"}}
+
+	if _, err := computeStartOrder([]*Process{a, b}); err == nil {
+		t.Fatal("expected an error for a dependency cycle, got nil")
+	}
+}
+
+func TestMetricsHandler_ReportsProcessSamples(t *testing.T) {
+	savedProcesses := config.Processes
+	defer func() { config.Processes = savedProcesses }()
+
+	p := &Process{Name: "metricsTestProcess", RestartCount: 2, LastExitCode: 1}
+	p.setHealthy(true)
+	config.Processes = []*Process{p}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	metricsHandler(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`processmanager_process_up{name="metricsTestProcess"} 1`,
+		`processmanager_process_restarts_total{name="metricsTestProcess"} 2`,
+		`processmanager_process_last_exit_code{name="metricsTestProcess"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestCheckExecHealth(t *testing.T) {
+	healthy := &Process{
+		Name: "healthyProcess",
+		HealthCheck: HealthCheck{
+			Type:    "exec",
+			Command: "true",
+		},
+	}
+	if !healthy.checkExecHealth() {
+		t.Error("Expected checkExecHealth to report healthy for a command that exits 0")
+	}
+
+	unhealthy := &Process{
+		Name: "unhealthyProcess",
+		HealthCheck: HealthCheck{
+			Type:    "exec",
+			Command: "false",
+		},
+	}
+	if unhealthy.checkExecHealth() {
+		t.Error("Expected checkExecHealth to report unhealthy for a command that exits non-zero")
+	}
+}